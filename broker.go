@@ -0,0 +1,107 @@
+package enchant
+
+/*
+#include <stdlib.h>
+#include "enchant/enchant.h"
+
+extern void goBrokerDescribeCb(char *name, char *desc, char *file, void *data);
+extern void goDictDescribeCb(char *tag, char *name, char *desc, char *file, void *data);
+
+static void brokerDescribeTrampoline(const char * const name, const char * const desc, const char * const file, void *data) {
+    goBrokerDescribeCb((char*)name, (char*)desc, (char*)file, data);
+}
+
+static void dictDescribeTrampoline(const char * const tag, const char * const name, const char * const desc, const char * const file, void *data) {
+    goDictDescribeCb((char*)tag, (char*)name, (char*)desc, (char*)file, data);
+}
+
+static void broker_describe(EnchantBroker *broker, void *data) {
+    enchant_broker_describe(broker, brokerDescribeTrampoline, data);
+}
+
+static void broker_list_dicts(EnchantBroker *broker, void *data) {
+    enchant_broker_list_dicts(broker, dictDescribeTrampoline, data);
+}
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// ProviderInfo describes a single spell-checking backend (provider)
+// known to the broker, such as Aspell or Hunspell.
+type ProviderInfo struct {
+	Name        string
+	Description string
+	File        string
+}
+
+// DictInfo describes a single dictionary known to the broker.
+type DictInfo struct {
+	LangTag     string
+	Provider    string
+	Description string
+	File        string
+}
+
+// Providers lists the spell-checking backends the broker has found,
+// via enchant_broker_describe.
+func (e *Enchant) Providers() []ProviderInfo {
+	var providers []ProviderInfo
+	h := cgo.NewHandle(&providers)
+	defer h.Delete()
+
+	C.broker_describe(e.broker, unsafe.Pointer(&h))
+	return providers
+}
+
+// ListDicts lists the dictionaries installed on the system, via
+// enchant_broker_list_dicts.
+func (e *Enchant) ListDicts() []DictInfo {
+	var dicts []DictInfo
+	h := cgo.NewHandle(&dicts)
+	defer h.Delete()
+
+	C.broker_list_dicts(e.broker, unsafe.Pointer(&h))
+	return dicts
+}
+
+// SetOrdering controls which providers the broker tries, and in what
+// order, when a dictionary is requested for langTag. ordering is a
+// comma-separated list of provider names, e.g. "aspell,hunspell"; use
+// "*" for langTag to set the default used when no more specific
+// ordering matches.
+// This wraps enchant_broker_set_ordering.
+func (e *Enchant) SetOrdering(langTag, ordering string) {
+	cTag := C.CString(langTag)
+	defer C.free(unsafe.Pointer(cTag))
+	cOrdering := C.CString(ordering)
+	defer C.free(unsafe.Pointer(cOrdering))
+
+	C.enchant_broker_set_ordering(e.broker, cTag, cOrdering)
+}
+
+//export goBrokerDescribeCb
+func goBrokerDescribeCb(name, desc, file *C.char, data unsafe.Pointer) {
+	h := *(*cgo.Handle)(data)
+	providers := h.Value().(*[]ProviderInfo)
+	*providers = append(*providers, ProviderInfo{
+		Name:        C.GoString(name),
+		Description: C.GoString(desc),
+		File:        C.GoString(file),
+	})
+}
+
+//export goDictDescribeCb
+func goDictDescribeCb(tag, name, desc, file *C.char, data unsafe.Pointer) {
+	h := *(*cgo.Handle)(data)
+	dicts := h.Value().(*[]DictInfo)
+	*dicts = append(*dicts, DictInfo{
+		LangTag:     C.GoString(tag),
+		Provider:    C.GoString(name),
+		Description: C.GoString(desc),
+		File:        C.GoString(file),
+	})
+}
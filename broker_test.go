@@ -0,0 +1,39 @@
+package enchant
+
+import "testing"
+
+func TestProvidersAndListDicts(t *testing.T) {
+	e, err := NewEnchant()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Free()
+
+	providers := e.Providers()
+	if len(providers) == 0 {
+		t.Skip("no enchant providers installed")
+	}
+	for _, p := range providers {
+		if p.Name == "" {
+			t.Errorf("Providers() returned a ProviderInfo with an empty Name: %#v", p)
+		}
+	}
+
+	for _, d := range e.ListDicts() {
+		if d.LangTag == "" || d.Provider == "" {
+			t.Errorf("ListDicts() returned a DictInfo with an empty LangTag/Provider: %#v", d)
+		}
+	}
+}
+
+func TestSetOrdering(t *testing.T) {
+	e, err := NewEnchant()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Free()
+
+	// SetOrdering only records a preference with the broker; it needs
+	// no installed dictionary, so there's no reason to skip it.
+	e.SetOrdering("en", "hunspell,aspell")
+}
@@ -0,0 +1,194 @@
+package enchant
+
+import (
+	"iter"
+
+	"github.com/taruti/enchant/tokenize"
+)
+
+// dictionary is the subset of Dict's behavior Checker needs. It's
+// kept as an interface, rather than using Dict directly, so tests can
+// drive Checker with a fake in place of a live, cgo-backed Dict.
+type dictionary interface {
+	Check(word string) bool
+	StoreReplacement(misspelled, corrected string) error
+	Add(word string) error
+}
+
+// Checker walks the misspelled words in a piece of text, modeled on
+// pyenchant's SpellChecker. Callers drive it with Next and inspect the
+// current word with Word, Offset and Length; Replace, Ignore and Add
+// let them act on that word before moving on.
+type Checker struct {
+	dict      dictionary
+	tokenizer tokenize.Tokenizer
+	text      string
+	base      int // offset in text at which the current pull iterator starts
+
+	pull     func() (tokenize.Token, error, bool)
+	pullStop func()
+
+	word   string
+	offset int
+	length int
+
+	replacements map[string]string
+	ignored      map[string]bool
+}
+
+// NewChecker returns a Checker that scans text for words d does not
+// recognize, splitting text into words with the default Unicode
+// tokenizer. Use NewCheckerWithTokenizer to plug in language-specific
+// rules instead.
+func (d Dict) NewChecker(text string) *Checker {
+	return d.NewCheckerWithTokenizer(text, tokenize.Unicode{})
+}
+
+// NewCheckerWithTokenizer is like NewChecker, but splits text into
+// words using t instead of the default Unicode tokenizer.
+func (d Dict) NewCheckerWithTokenizer(text string, t tokenize.Tokenizer) *Checker {
+	return newChecker(d, text, t)
+}
+
+func newChecker(d dictionary, text string, t tokenize.Tokenizer) *Checker {
+	c := &Checker{
+		dict:         d,
+		tokenizer:    t,
+		text:         text,
+		replacements: make(map[string]string),
+		ignored:      make(map[string]bool),
+	}
+	c.resetIterator()
+	return c
+}
+
+// Next advances to the next misspelled word and reports whether one
+// was found. Once Next returns false the scan is over; Text returns
+// the (possibly edited) result.
+func (c *Checker) Next() bool {
+	for {
+		tok, ok := c.nextToken()
+		if !ok {
+			return false
+		}
+		offset, length := tok.Offset, len(tok.Word)
+		word := tok.Word
+
+		if repl, has := c.replacements[word]; has {
+			c.replaceAt(offset, length, repl)
+			continue
+		}
+		if c.ignored[word] {
+			continue
+		}
+		if c.dict.Check(word) {
+			continue
+		}
+
+		c.word = word
+		c.offset = offset
+		c.length = length
+		return true
+	}
+}
+
+// Word returns the current misspelled word.
+func (c *Checker) Word() string { return c.word }
+
+// Offset returns the byte offset of the current word within Text().
+func (c *Checker) Offset() int { return c.offset }
+
+// Length returns the byte length of the current word.
+func (c *Checker) Length() int { return c.length }
+
+// Context returns up to n bytes of text on either side of the current
+// word, clamped to the bounds of Text().
+func (c *Checker) Context(n int) string {
+	start := c.offset - n
+	if start < 0 {
+		start = 0
+	}
+	end := c.offset + c.length + n
+	if end > len(c.text) {
+		end = len(c.text)
+	}
+	return c.text[start:end]
+}
+
+// Replace substitutes the current word with newWord in the
+// underlying text, just for this occurrence.
+func (c *Checker) Replace(newWord string) {
+	c.replaceAt(c.offset, c.length, newWord)
+}
+
+// ReplaceAlways replaces the current word with newWord, teaches the
+// dictionary the correction via StoreReplacement, and remembers it so
+// every later occurrence of the same misspelling in this pass is
+// replaced too.
+func (c *Checker) ReplaceAlways(newWord string) error {
+	err := c.dict.StoreReplacement(c.word, newWord)
+	c.replacements[c.word] = newWord
+	c.Replace(newWord)
+	return err
+}
+
+// Ignore skips the current occurrence. Later occurrences of the same
+// word are still reported.
+func (c *Checker) Ignore() {}
+
+// IgnoreAlways skips the current occurrence and every later one of
+// the same word for the rest of this pass.
+func (c *Checker) IgnoreAlways() {
+	c.ignored[c.word] = true
+}
+
+// Add adds the current word to the dictionary's personal word list.
+func (c *Checker) Add() error {
+	return c.dict.Add(c.word)
+}
+
+// Text returns the text as edited so far by Replace/ReplaceAlways.
+func (c *Checker) Text() string { return c.text }
+
+// Close releases the resources backing the checker's tokenizer
+// iterator. Callers that run Next to completion (until it returns
+// false) don't need to call Close; it's only needed when a Checker is
+// abandoned partway through a scan.
+func (c *Checker) Close() {
+	if c.pullStop != nil {
+		c.pullStop()
+	}
+}
+
+func (c *Checker) replaceAt(offset, length int, repl string) {
+	c.text = c.text[:offset] + repl + c.text[offset+length:]
+	c.base = offset + len(repl)
+	c.resetIterator()
+}
+
+// resetIterator (re)starts tokenization from c.base. It's called once
+// up front and again after every edit, rather than on every Next,
+// so a scan over unedited text advances through the tokenizer's
+// iter.Seq2 incrementally instead of re-running it from scratch for
+// each word.
+func (c *Checker) resetIterator() {
+	if c.pullStop != nil {
+		c.pullStop()
+	}
+	c.pull, c.pullStop = iter.Pull2(c.tokenizer.Tokens(c.text[c.base:]))
+}
+
+// nextToken returns the next token at or after c.base.
+func (c *Checker) nextToken() (tokenize.Token, bool) {
+	for {
+		tok, err, ok := c.pull()
+		if !ok {
+			return tokenize.Token{}, false
+		}
+		if err != nil {
+			continue
+		}
+		tok.Offset += c.base
+		return tok, true
+	}
+}
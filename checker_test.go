@@ -0,0 +1,126 @@
+package enchant
+
+import (
+	"testing"
+
+	"github.com/taruti/enchant/tokenize"
+)
+
+// fakeDict is a dictionary stand-in for tests, so Checker can be
+// exercised without a live, cgo-backed Dict.
+type fakeDict struct {
+	bad    map[string]bool
+	stored map[string]string
+	added  []string
+}
+
+func (f *fakeDict) Check(word string) bool { return !f.bad[word] }
+
+func (f *fakeDict) StoreReplacement(misspelled, corrected string) error {
+	if f.stored == nil {
+		f.stored = make(map[string]string)
+	}
+	f.stored[misspelled] = corrected
+	return nil
+}
+
+func (f *fakeDict) Add(word string) error {
+	f.added = append(f.added, word)
+	return nil
+}
+
+func TestCheckerNextFindsMisspelledWords(t *testing.T) {
+	d := &fakeDict{bad: map[string]bool{"helo": true}}
+	c := newChecker(d, "say helo world", tokenize.Unicode{})
+
+	if !c.Next() {
+		t.Fatal("Next() = false, want true")
+	}
+	if c.Word() != "helo" {
+		t.Fatalf("Word() = %q, want %q", c.Word(), "helo")
+	}
+	if c.Offset() != 4 || c.Length() != 4 {
+		t.Fatalf("Offset()/Length() = %d/%d, want 4/4", c.Offset(), c.Length())
+	}
+	if c.Next() {
+		t.Fatalf("unexpected second misspelled word: %q", c.Word())
+	}
+}
+
+func TestCheckerReplaceAdjustsLaterOffsets(t *testing.T) {
+	d := &fakeDict{bad: map[string]bool{"helo": true, "wrld": true}}
+	c := newChecker(d, "helo wrld", tokenize.Unicode{})
+
+	if !c.Next() || c.Word() != "helo" {
+		t.Fatalf("expected helo, got %q", c.Word())
+	}
+	c.Replace("hello") // longer replacement must shift what follows
+
+	if !c.Next() || c.Word() != "wrld" {
+		t.Fatalf("expected wrld after replace, got %q", c.Word())
+	}
+	if want := len("hello "); c.Offset() != want {
+		t.Fatalf("Offset() = %d, want %d", c.Offset(), want)
+	}
+	c.Replace("world")
+
+	if c.Next() {
+		t.Fatalf("unexpected further misspelled word: %q", c.Word())
+	}
+	if want := "hello world"; c.Text() != want {
+		t.Fatalf("Text() = %q, want %q", c.Text(), want)
+	}
+}
+
+func TestCheckerReplaceAlwaysAppliesToLaterOccurrences(t *testing.T) {
+	d := &fakeDict{bad: map[string]bool{"helo": true}}
+	c := newChecker(d, "helo there, helo again", tokenize.Unicode{})
+
+	if !c.Next() || c.Word() != "helo" {
+		t.Fatalf("expected first helo")
+	}
+	if err := c.ReplaceAlways("hello"); err != nil {
+		t.Fatalf("ReplaceAlways: %v", err)
+	}
+	if got := d.stored["helo"]; got != "hello" {
+		t.Fatalf("StoreReplacement not recorded: got %q", got)
+	}
+
+	// The second occurrence is replaced automatically rather than
+	// reported as misspelled again.
+	if c.Next() {
+		t.Fatalf("unexpected misspelled word after ReplaceAlways: %q", c.Word())
+	}
+	if want := "hello there, hello again"; c.Text() != want {
+		t.Fatalf("Text() = %q, want %q", c.Text(), want)
+	}
+}
+
+func TestCheckerIgnoreAlwaysSkipsLaterOccurrences(t *testing.T) {
+	d := &fakeDict{bad: map[string]bool{"helo": true}}
+	c := newChecker(d, "helo helo", tokenize.Unicode{})
+
+	if !c.Next() || c.Word() != "helo" {
+		t.Fatalf("expected first helo")
+	}
+	c.IgnoreAlways()
+
+	if c.Next() {
+		t.Fatalf("unexpected misspelled word after IgnoreAlways: %q", c.Word())
+	}
+}
+
+func TestCheckerAdd(t *testing.T) {
+	d := &fakeDict{bad: map[string]bool{"helo": true}}
+	c := newChecker(d, "helo", tokenize.Unicode{})
+
+	if !c.Next() {
+		t.Fatal("Next() = false, want true")
+	}
+	if err := c.Add(); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if len(d.added) != 1 || d.added[0] != "helo" {
+		t.Fatalf("added = %#v, want [helo]", d.added)
+	}
+}
@@ -7,16 +7,14 @@ package enchant
 #include <stdlib.h>
 #include <sys/types.h>
 #include "enchant/enchant.h"
-
-static char* getString(char ** c, int i) {
-    return c[i];
-}
 */
 import "C"
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"unsafe"
 )
 
@@ -25,11 +23,20 @@ type Enchant struct {
 	broker *C.EnchantBroker
 }
 
-// Dict encapsulates dictionaries
+// Dict encapsulates dictionaries. Like the underlying Enchant
+// dictionary, a Dict is not safe for concurrent use by multiple
+// goroutines except through its own methods, which serialize access
+// with mu.
 type Dict struct {
-	dict   *C.EnchantDict
+	dict *C.EnchantDict
+	mu   *sync.Mutex
 }
 
+// ErrNoDictionary is returned by LoadDict when the broker has no
+// provider at all for the requested language, as opposed to a
+// provider failing to load it.
+var ErrNoDictionary = errors.New("enchant: no dictionary available for this language")
+
 // NewEnchant creates a new Enchant instance for access
 // to the rest of the Enchant API.
 //
@@ -61,8 +68,11 @@ func (e *Enchant) Free() {
 	C.enchant_broker_free(e.broker)
 }
 
-// FreeDict frees a dictionary.
+// FreeDict frees a dictionary. d must not be used afterwards.
 func (e *Enchant) FreeDict(d *Dict) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	C.enchant_broker_free_dict(e.broker, d.dict)
 }
 
@@ -84,15 +94,20 @@ func (e *Enchant) DictExists(name string) bool {
 // It takes a language code name, such as "en_GB", as string
 // argument, and it returns a EnchantDict representation
 // of this dictionary.
-func (e *Enchant) LoadDict(name string) (Dict,error) {
+func (e *Enchant) LoadDict(name string) (Dict, error) {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
 	dict := C.enchant_broker_request_dict(e.broker, cName)
 	if dict == nil {
-		return Dict{}, errors.New("Cannot load dictionary")
+		if cErr := C.enchant_broker_get_error(e.broker); cErr != nil {
+			if msg := C.GoString(cErr); msg != "" {
+				return Dict{}, fmt.Errorf("enchant: cannot load dictionary %q: %s", name, msg)
+			}
+		}
+		return Dict{}, ErrNoDictionary
 	}
-	return Dict{dict}, nil
+	return Dict{dict: dict, mu: new(sync.Mutex)}, nil
 }
 
 // Check whether a given word is in the currently loaded dictionary.
@@ -104,12 +119,135 @@ func (d Dict) Check(word string) bool {
 		return true
 	}
 
-	cWord := (*C.char)(unsafe.Pointer((*reflect.StringHeader)(unsafe.Pointer(&word)).Data))
-	size := C.ssize_t(uintptr(len(word)))
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cWord, size := wordPtr(word)
 
 	return C.enchant_dict_check(d.dict, cWord, size) == 0
 }
 
+// wordPtr returns an unsafe, zero-copy *C.char pointing at word's
+// underlying bytes, along with its length. The returned pointer is
+// only valid while word itself is still reachable, so it must not be
+// stashed away past the C call it is passed to.
+func wordPtr(word string) (*C.char, C.ssize_t) {
+	cWord := (*C.char)(unsafe.Pointer((*reflect.StringHeader)(unsafe.Pointer(&word)).Data))
+	return cWord, C.ssize_t(uintptr(len(word)))
+}
+
+// Add adds word to the user's personal dictionary.
+// This wraps enchant_dict_add.
+func (d Dict) Add(word string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cWord, size := wordPtr(word)
+	C.enchant_dict_add(d.dict, cWord, size)
+	return d.lastErrorLocked()
+}
+
+// AddToSession adds word to the current spell-checking session only,
+// without persisting it to the personal dictionary.
+// This wraps enchant_dict_add_to_session.
+func (d Dict) AddToSession(word string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cWord, size := wordPtr(word)
+	C.enchant_dict_add_to_session(d.dict, cWord, size)
+	return d.lastErrorLocked()
+}
+
+// Remove removes word from the user's personal dictionary.
+// This wraps enchant_dict_remove.
+func (d Dict) Remove(word string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cWord, size := wordPtr(word)
+	C.enchant_dict_remove(d.dict, cWord, size)
+	return d.lastErrorLocked()
+}
+
+// RemoveFromSession removes word from the current spell-checking
+// session's exclude list.
+// This wraps enchant_dict_remove_from_session.
+func (d Dict) RemoveFromSession(word string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cWord, size := wordPtr(word)
+	C.enchant_dict_remove_from_session(d.dict, cWord, size)
+	return d.lastErrorLocked()
+}
+
+// IsAdded reports whether word has been added to the personal
+// dictionary or the current session.
+// This wraps enchant_dict_is_added.
+func (d Dict) IsAdded(word string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cWord, size := wordPtr(word)
+	return C.enchant_dict_is_added(d.dict, cWord, size) != 0
+}
+
+// IsRemoved reports whether word has been removed from the
+// dictionary, either permanently or for the current session.
+// This wraps enchant_dict_is_removed.
+func (d Dict) IsRemoved(word string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cWord, size := wordPtr(word)
+	return C.enchant_dict_is_removed(d.dict, cWord, size) != 0
+}
+
+// StoreReplacement records that misspelled was corrected to
+// corrected, so the dictionary can suggest corrected earlier next
+// time misspelled is looked up.
+// This wraps enchant_dict_store_replacement.
+func (d Dict) StoreReplacement(misspelled, corrected string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cMis, misSize := wordPtr(misspelled)
+	cCor, corSize := wordPtr(corrected)
+	C.enchant_dict_store_replacement(d.dict, cMis, misSize, cCor, corSize)
+	return d.lastErrorLocked()
+}
+
+// LastError returns the error currently recorded on d, if any. Call
+// it right after Check or Suggest, whose own return values can't
+// carry an error, to tell a backend failure apart from an ordinary
+// "not found" result. Enchant has no API to clear a dict's error
+// state, so LastError reports whatever enchant_dict_get_error
+// currently holds; if no call on d has failed since it raised its
+// one real error, later calls will keep reporting that same error
+// rather than going back to nil. If other calls on d run
+// concurrently, LastError can only be attributed to "some recent
+// call", not necessarily the one that immediately preceded it.
+func (d Dict) LastError() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lastErrorLocked()
+}
+
+// lastErrorLocked is LastError's body, for callers that already hold
+// d.mu.
+func (d Dict) lastErrorLocked() error {
+	cErr := C.enchant_dict_get_error(d.dict)
+	if cErr == nil {
+		return nil
+	}
+	msg := C.GoString(cErr)
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
+}
+
 // Suggest words based on the given word.
 // This is a wrapper for enchant_dict_suggest.
 // It returns a slice of suggestion strings.
@@ -118,24 +256,43 @@ func (d Dict) Suggest(word string) (suggestions []string) {
 		return suggestions
 	}
 
-	cWord := C.CString(word)
-	defer C.free(unsafe.Pointer(cWord))
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	size := uintptr(len(word))
-	s := (*C.ssize_t)(unsafe.Pointer(&size))
+	cWord, size := wordPtr(word)
 
-	var n int
-	nSugg := uintptr(n)
-	ns := (*C.size_t)(unsafe.Pointer(&nSugg))
+	var n C.size_t
+	response := C.enchant_dict_suggest(d.dict, cWord, size, &n)
+	if response == nil {
+		return suggestions
+	}
+	defer C.enchant_dict_free_string_list(d.dict, response)
 
-	// get the suggestions; ns will be modified to store the
-	// number of suggestions returned
-	response := C.enchant_dict_suggest(d.dict, cWord, *s, ns)
+	return decodeSuggestions(unsafe.Slice(response, int(n)))
+}
 
-	for i := 0; i < int(*ns); i++ {
-		ci := C.int(i)
-		suggestions = append(suggestions, C.GoString(C.getString(response, ci)))
+// decodeSuggestions copies each C string in cStrings into a Go
+// string. It walks the array directly via a single cgo array
+// conversion rather than issuing one cgo call per element.
+func decodeSuggestions(cStrings []*C.char) []string {
+	suggestions := make([]string, len(cStrings))
+	for i, cs := range cStrings {
+		suggestions[i] = C.GoString(cs)
 	}
-
 	return suggestions
 }
+
+// newCStrings returns n C-allocated copies of s, and a func that frees
+// them. BenchmarkDecodeSuggestions builds its input with it, since a
+// _test.go file can't use cgo itself.
+func newCStrings(n int, s string) ([]*C.char, func()) {
+	cStrings := make([]*C.char, n)
+	for i := range cStrings {
+		cStrings[i] = C.CString(s)
+	}
+	return cStrings, func() {
+		for _, cs := range cStrings {
+			C.free(unsafe.Pointer(cs))
+		}
+	}
+}
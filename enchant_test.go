@@ -0,0 +1,41 @@
+package enchant
+
+import "testing"
+
+// BenchmarkDecodeSuggestions exercises the C-array-to-[]string copy
+// Suggest runs on enchant_dict_suggest's result, with a fixed 20-word
+// result so the benchmark is deterministic and doesn't depend on any
+// dictionary being installed.
+func BenchmarkDecodeSuggestions(b *testing.B) {
+	const n = 20
+	cStrings, free := newCStrings(n, "suggestion")
+	defer free()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		decodeSuggestions(cStrings)
+	}
+}
+
+// BenchmarkSuggest exercises the full Suggest call, including the
+// cgo round trip to libenchant. Unlike BenchmarkDecodeSuggestions, it
+// needs an en_US dictionary installed locally and isn't meant to gate
+// CI; it skips itself when one isn't available.
+func BenchmarkSuggest(b *testing.B) {
+	e, err := NewEnchant()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer e.Free()
+
+	dict, err := e.LoadDict("en_US")
+	if err != nil {
+		b.Skipf("no en_US dictionary installed: %v", err)
+	}
+	defer e.FreeDict(&dict)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dict.Suggest("hsiptal")
+	}
+}
@@ -0,0 +1,238 @@
+// Package tokenize splits text into words for spell-checking,
+// modeled on pyenchant's tokenize module.
+package tokenize
+
+import (
+	"iter"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a single word yielded by a Tokenizer, together with its
+// position in the text it was read from.
+type Token struct {
+	Word   string
+	Offset int // byte offset into the original text
+	Rune   int // rune offset into the original text
+}
+
+// Tokenizer splits text into a sequence of word Tokens.
+type Tokenizer interface {
+	Tokens(text string) iter.Seq2[Token, error]
+}
+
+// Unicode is the default Tokenizer. It splits text into maximal runs
+// of Unicode letters and marks, so accented words such as "café" or
+// "naïve" stay intact, and discards everything else.
+type Unicode struct{}
+
+// Tokens implements Tokenizer.
+func (Unicode) Tokens(text string) iter.Seq2[Token, error] {
+	return func(yield func(Token, error) bool) {
+		start := -1
+		startRune := -1
+		runeOffset := 0
+		for byteOffset, r := range text {
+			if isWordRune(r) {
+				if start == -1 {
+					start = byteOffset
+					startRune = runeOffset
+				}
+			} else if start != -1 {
+				if !yield(Token{Word: text[start:byteOffset], Offset: start, Rune: startRune}, nil) {
+					return
+				}
+				start = -1
+			}
+			runeOffset++
+		}
+		if start != -1 {
+			yield(Token{Word: text[start:], Offset: start, Rune: startRune}, nil)
+		}
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// English is a Tokenizer tuned for English text: it keeps an
+// apostrophe inside a word so contractions like "don't" and "it's"
+// aren't split in two, and can optionally strip a trailing
+// possessive "'s".
+type English struct {
+	// StripPossessive removes a trailing "'s" from each token, so
+	// "dog's" is returned as "dog".
+	StripPossessive bool
+}
+
+// Tokens implements Tokenizer.
+func (e English) Tokens(text string) iter.Seq2[Token, error] {
+	return func(yield func(Token, error) bool) {
+		type rpos struct {
+			r      rune
+			offset int
+		}
+		runes := make([]rpos, 0, len(text))
+		for i, r := range text {
+			runes = append(runes, rpos{r, i})
+		}
+		n := len(runes)
+
+		byteEnd := func(i int) int {
+			if i < n {
+				return runes[i].offset
+			}
+			return len(text)
+		}
+
+		i := 0
+		for i < n {
+			if !isWordRune(runes[i].r) {
+				i++
+				continue
+			}
+			start := i
+			for i < n {
+				r := runes[i].r
+				if isWordRune(r) {
+					i++
+					continue
+				}
+				if isApostrophe(r) && i+1 < n && isWordRune(runes[i+1].r) {
+					i++
+					continue
+				}
+				break
+			}
+			word := text[runes[start].offset:byteEnd(i)]
+			if e.StripPossessive {
+				word = stripPossessive(word)
+			}
+			if !yield(Token{Word: word, Offset: runes[start].offset, Rune: start}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func stripPossessive(word string) string {
+	lower := strings.ToLower(word)
+	if strings.HasSuffix(lower, "'s") || strings.HasSuffix(lower, "’s") {
+		r := []rune(word)
+		return string(r[:len(r)-2])
+	}
+	return word
+}
+
+// URLPattern matches things that look like URLs, such as
+// "https://example.com/path".
+var URLPattern = regexp.MustCompile(`\w+://\S+`)
+
+// EmailPattern matches things that look like email addresses.
+var EmailPattern = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+
+// PatternFilter wraps a Tokenizer and discards any of its tokens that
+// fall inside a match of Pattern, e.g. to keep URLs or email
+// addresses from being torn apart into separate misspelled words.
+type PatternFilter struct {
+	Tokenizer Tokenizer
+	Pattern   *regexp.Regexp
+}
+
+// Tokens implements Tokenizer.
+func (f PatternFilter) Tokens(text string) iter.Seq2[Token, error] {
+	spans := f.Pattern.FindAllStringIndex(text, -1)
+	return func(yield func(Token, error) bool) {
+		for tok, err := range f.Tokenizer.Tokens(text) {
+			if err == nil && inAnySpan(tok.Offset, len(tok.Word), spans) {
+				continue
+			}
+			if !yield(tok, err) {
+				return
+			}
+		}
+	}
+}
+
+func inAnySpan(offset, length int, spans [][]int) bool {
+	end := offset + length
+	for _, s := range spans {
+		if offset >= s[0] && end <= s[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipURLs wraps t in a Tokenizer that discards tokens that are part
+// of something that looks like a URL.
+func SkipURLs(t Tokenizer) Tokenizer {
+	return PatternFilter{Tokenizer: t, Pattern: URLPattern}
+}
+
+// SkipEmails wraps t in a Tokenizer that discards tokens that are
+// part of something that looks like an email address.
+func SkipEmails(t Tokenizer) Tokenizer {
+	return PatternFilter{Tokenizer: t, Pattern: EmailPattern}
+}
+
+// wikiWordPart matches one hump of a CamelCase/WikiWord, e.g. "Spell"
+// or "Checker" within "SpellChecker".
+var wikiWordPart = regexp.MustCompile(`\p{Lu}[^\p{Lu}]*|[^\p{Lu}]+`)
+
+// WikiWords wraps t in a Tokenizer that splits any CamelCase
+// "WikiWord" token (e.g. "SpellChecker") into its constituent
+// sub-words ("Spell", "Checker"), with correctly adjusted offsets.
+type WikiWords struct {
+	Tokenizer Tokenizer
+}
+
+// Tokens implements Tokenizer.
+func (w WikiWords) Tokens(text string) iter.Seq2[Token, error] {
+	return func(yield func(Token, error) bool) {
+		for tok, err := range w.Tokenizer.Tokens(text) {
+			if err != nil {
+				if !yield(tok, err) {
+					return
+				}
+				continue
+			}
+			if !isWikiWord(tok.Word) {
+				if !yield(tok, nil) {
+					return
+				}
+				continue
+			}
+			for _, loc := range wikiWordPart.FindAllStringIndex(tok.Word, -1) {
+				sub := Token{
+					Word:   tok.Word[loc[0]:loc[1]],
+					Offset: tok.Offset + loc[0],
+					Rune:   tok.Rune + utf8.RuneCountInString(tok.Word[:loc[0]]),
+				}
+				if !yield(sub, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func isWikiWord(word string) bool {
+	var upper, lower int
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	return upper >= 2 && lower >= 1
+}
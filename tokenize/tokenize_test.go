@@ -0,0 +1,128 @@
+package tokenize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func collect(t *testing.T, tok Tokenizer, text string) []Token {
+	t.Helper()
+	var got []Token
+	for tk, err := range tok.Tokens(text) {
+		if err != nil {
+			t.Fatalf("Tokens(%q): unexpected error: %v", text, err)
+		}
+		got = append(got, tk)
+	}
+	return got
+}
+
+func words(toks []Token) []string {
+	out := make([]string, len(toks))
+	for i, tk := range toks {
+		out[i] = tk.Word
+	}
+	return out
+}
+
+func TestUnicodeTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want []Token
+	}{
+		{"", nil},
+		{"hello world", []Token{
+			{Word: "hello", Offset: 0, Rune: 0},
+			{Word: "world", Offset: 6, Rune: 6},
+		}},
+		{"  café  naïve", []Token{
+			{Word: "café", Offset: 2, Rune: 2},
+			{Word: "naïve", Offset: 9, Rune: 8},
+		}},
+		{"don't", []Token{
+			{Word: "don", Offset: 0, Rune: 0},
+			{Word: "t", Offset: 4, Rune: 4},
+		}},
+	}
+	for _, tt := range tests {
+		got := collect(t, Unicode{}, tt.text)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unicode{}.Tokens(%q) = %#v, want %#v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEnglishTokensKeepsApostrophes(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"don't stop", []string{"don't", "stop"}},
+		{"it's the dog's bone", []string{"it's", "the", "dog's", "bone"}},
+		{"'quoted'", []string{"quoted"}},
+	}
+	for _, tt := range tests {
+		got := words(collect(t, English{}, tt.text))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("English{}.Tokens(%q) = %#v, want %#v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEnglishTokensOffsets(t *testing.T) {
+	toks := collect(t, English{}, "hi don't")
+	want := []Token{
+		{Word: "hi", Offset: 0, Rune: 0},
+		{Word: "don't", Offset: 3, Rune: 3},
+	}
+	if !reflect.DeepEqual(toks, want) {
+		t.Errorf("English{}.Tokens offsets = %#v, want %#v", toks, want)
+	}
+}
+
+func TestEnglishStripPossessive(t *testing.T) {
+	got := words(collect(t, English{StripPossessive: true}, "the dog's bone"))
+	want := []string{"the", "dog", "bone"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StripPossessive = %#v, want %#v", got, want)
+	}
+}
+
+func TestSkipURLs(t *testing.T) {
+	text := "see http://example.com/path for info"
+	got := words(collect(t, SkipURLs(Unicode{}), text))
+	want := []string{"see", "for", "info"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SkipURLs = %#v, want %#v", got, want)
+	}
+}
+
+func TestSkipEmails(t *testing.T) {
+	text := "contact me@example.com today"
+	got := words(collect(t, SkipEmails(Unicode{}), text))
+	want := []string{"contact", "today"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SkipEmails = %#v, want %#v", got, want)
+	}
+}
+
+func TestWikiWords(t *testing.T) {
+	toks := collect(t, WikiWords{Tokenizer: Unicode{}}, "see SpellChecker here")
+	want := []Token{
+		{Word: "see", Offset: 0, Rune: 0},
+		{Word: "Spell", Offset: 4, Rune: 4},
+		{Word: "Checker", Offset: 9, Rune: 9},
+		{Word: "here", Offset: 17, Rune: 17},
+	}
+	if !reflect.DeepEqual(toks, want) {
+		t.Errorf("WikiWords.Tokens = %#v, want %#v", toks, want)
+	}
+}
+
+func TestWikiWordsLeavesOrdinaryWordsAlone(t *testing.T) {
+	got := words(collect(t, WikiWords{Tokenizer: Unicode{}}, "hello world"))
+	want := []string{"hello", "world"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WikiWords.Tokens = %#v, want %#v", got, want)
+	}
+}